@@ -0,0 +1,65 @@
+// Command conjur-configure is a thin CLI wrapper around
+// conjurapi.Config.Bootstrap, for interactively setting up a .conjurrc and
+// netrc against a Conjur appliance.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Nirupma-Verma/authn-jwt-gitlab/internal/conjurapi"
+)
+
+func main() {
+	applianceURL := flag.String("appliance-url", "", "URL of the Conjur appliance")
+	account := flag.String("account", "", "Conjur account")
+	authnType := flag.String("authn-type", "", "Authenticator type, e.g. ldap, oidc (blank for default)")
+	serviceID := flag.String("service-id", "", "Authenticator service-id, if required by authn-type")
+	login := flag.String("login", "", "Login name to authenticate as")
+	force := flag.Bool("force", false, "Overwrite an existing .conjurrc")
+	flag.Parse()
+
+	if *applianceURL == "" || *account == "" || *login == "" {
+		fmt.Fprintln(os.Stderr, "appliance-url, account, and login are required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	password := promptSecret("Password: ")
+
+	opts := conjurapi.BootstrapOptions{
+		ApplianceURL:       *applianceURL,
+		Account:            *account,
+		AuthnType:          *authnType,
+		ServiceID:          *serviceID,
+		Login:              *login,
+		Password:           password,
+		Force:              *force,
+		ConfirmFingerprint: confirmFingerprint,
+	}
+
+	config := &conjurapi.Config{}
+	if err := config.Bootstrap(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "conjur-configure: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Configured successfully.")
+}
+
+func confirmFingerprint(fingerprint string) bool {
+	fmt.Printf("Appliance certificate fingerprint: %s\nTrust this certificate? [y/N] ", fingerprint)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(answer)) == "y"
+}
+
+func promptSecret(prompt string) string {
+	fmt.Print(prompt)
+	reader := bufio.NewReader(os.Stdin)
+	password, _ := reader.ReadString('\n')
+	return strings.TrimSpace(password)
+}
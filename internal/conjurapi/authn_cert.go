@@ -0,0 +1,44 @@
+package conjurapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// AuthenticateCert exchanges the Client's configured client certificate for
+// a short-lived Conjur access token via the authn-cert authenticator. The
+// client certificate itself is presented at the TLS layer (see
+// httpClientFromConfig); this call only needs to hit the endpoint that
+// recognizes it.
+//
+// login is the Conjur identity (e.g. host/some-host) the certificate was
+// issued for.
+func (c *Client) AuthenticateCert(login string) ([]byte, error) {
+	if c.config.AuthnType != AuthnTypeCert {
+		return nil, fmt.Errorf("AuthenticateCert requires AuthnType %q, got %q", AuthnTypeCert, c.config.AuthnType)
+	}
+
+	url := c.applianceURL(
+		"/authn-cert/%s/%s/authenticate",
+		c.config.ServiceID,
+		login,
+	)
+
+	resp, err := c.httpClient.Post(url, "text/plain", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to authenticate via authn-cert: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read authn-cert response: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authn-cert authentication failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,112 @@
+package conjurapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// JWTProvider supplies the JWT used to authenticate via the jwt
+// authenticator. It is consulted fresh on every call to
+// Client.AuthenticateJWT, so implementations backed by a file or
+// environment variable naturally pick up a token that was rotated after
+// the Client was built (GitLab CI, GitHub Actions OIDC, and Kubernetes
+// projected volumes all rotate their tokens this way).
+type JWTProvider interface {
+	JWT() (string, error)
+}
+
+// FileJWTProvider reads the token from a file on every call, such as a
+// Kubernetes projected service-account-token volume.
+type FileJWTProvider struct {
+	Path string
+}
+
+// JWT implements JWTProvider.
+func (p FileJWTProvider) JWT() (string, error) {
+	data, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read JWT from %s: %s", p.Path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// EnvJWTProvider reads the token from an environment variable on every
+// call, such as GitLab CI's CI_JOB_JWT or a GitHub Actions OIDC token
+// fetched by the caller ahead of time.
+type EnvJWTProvider struct {
+	Var string
+}
+
+// JWT implements JWTProvider.
+func (p EnvJWTProvider) JWT() (string, error) {
+	v := os.Getenv(p.Var)
+	if v == "" {
+		return "", fmt.Errorf("Environment variable %s is not set", p.Var)
+	}
+	return v, nil
+}
+
+// staticJWTProvider returns a fixed token, for Config.JWTProvider when the
+// token was supplied directly as Config.JWTToken.
+type staticJWTProvider string
+
+func (p staticJWTProvider) JWT() (string, error) {
+	return string(p), nil
+}
+
+// JWTProvider returns the JWTProvider implied by c.JWTToken / c.JWTTokenPath,
+// for callers that don't need a custom source such as EnvJWTProvider.
+func (c *Config) JWTProvider() (JWTProvider, error) {
+	switch {
+	case c.JWTToken != "":
+		return staticJWTProvider(c.JWTToken), nil
+	case c.JWTTokenPath != "":
+		return FileJWTProvider{Path: c.JWTTokenPath}, nil
+	default:
+		return nil, fmt.Errorf("No JWT token source configured; set JWTToken or JWTTokenPath, or pass a JWTProvider explicitly")
+	}
+}
+
+// AuthenticateJWT exchanges the token returned by provider for a
+// short-lived Conjur access token via the jwt authenticator.
+func (c *Client) AuthenticateJWT(provider JWTProvider) ([]byte, error) {
+	if c.config.AuthnType != AuthnTypeJWT {
+		return nil, fmt.Errorf("AuthenticateJWT requires AuthnType %q, got %q", AuthnTypeJWT, c.config.AuthnType)
+	}
+
+	token, err := provider.JWT()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to obtain a JWT: %s", err)
+	}
+
+	authenticateURL := c.applianceURL("/authn-jwt/%s/%s/authenticate", c.config.ServiceID, c.config.Account)
+	if c.config.JWTHostID != "" {
+		authenticateURL = c.applianceURL(
+			"/authn-jwt/%s/%s/host/%s/authenticate",
+			c.config.ServiceID, c.config.Account, c.config.JWTHostID,
+		)
+	}
+
+	form := url.Values{"jwt": {token}}
+
+	resp, err := c.httpClient.PostForm(authenticateURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to authenticate via authn-jwt: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read authn-jwt response: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authn-jwt authentication failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	return body, nil
+}
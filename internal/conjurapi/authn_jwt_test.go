@@ -0,0 +1,149 @@
+package conjurapi
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tlsServerCertPEM returns server's leaf certificate PEM-encoded, so tests
+// can pin it as Config.SSLCert and make real HTTP calls against the
+// server without skipping TLS verification.
+func tlsServerCertPEM(server *httptest.Server) ([]byte, error) {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), nil
+}
+
+func TestFileJWTProvider(t *testing.T) {
+	t.Run("Reads the token from the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		assert.NoError(t, os.WriteFile(path, []byte("a-token\n"), 0600))
+
+		token, err := FileJWTProvider{Path: path}.JWT()
+		assert.NoError(t, err)
+		assert.Equal(t, "a-token", token)
+	})
+
+	t.Run("Returns an error when the file cannot be read", func(t *testing.T) {
+		_, err := FileJWTProvider{Path: filepath.Join(t.TempDir(), "missing")}.JWT()
+		assert.Error(t, err)
+	})
+}
+
+func TestEnvJWTProvider(t *testing.T) {
+	t.Run("Reads the token from the environment", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		os.Setenv("MY_JWT", "a-token")
+
+		token, err := EnvJWTProvider{Var: "MY_JWT"}.JWT()
+		assert.NoError(t, err)
+		assert.Equal(t, "a-token", token)
+	})
+
+	t.Run("Returns an error when the variable is unset", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		_, err := EnvJWTProvider{Var: "MY_JWT"}.JWT()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_JWTProvider(t *testing.T) {
+	t.Run("Returns a static provider for JWTToken", func(t *testing.T) {
+		config := Config{JWTToken: "a-token"}
+		provider, err := config.JWTProvider()
+		assert.NoError(t, err)
+
+		token, err := provider.JWT()
+		assert.NoError(t, err)
+		assert.Equal(t, "a-token", token)
+	})
+
+	t.Run("Returns a FileJWTProvider for JWTTokenPath", func(t *testing.T) {
+		config := Config{JWTTokenPath: "/path/to/token"}
+		provider, err := config.JWTProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, FileJWTProvider{Path: "/path/to/token"}, provider)
+	})
+
+	t.Run("Returns an error when neither is set", func(t *testing.T) {
+		_, err := (&Config{}).JWTProvider()
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_AuthenticateJWT(t *testing.T) {
+	newClient := func(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+		server := httptest.NewTLSServer(handler)
+
+		certPEM, err := tlsServerCertPEM(server)
+		assert.NoError(t, err)
+
+		config := Config{
+			Account:      "the-account",
+			ApplianceURL: server.URL,
+			AuthnType:    AuthnTypeJWT,
+			ServiceID:    "my-service",
+			JWTTokenPath: "/path/to/token",
+			SSLCert:      string(certPEM),
+		}
+
+		client, err := NewClientFromConfig(config)
+		assert.NoError(t, err)
+
+		return client, server
+	}
+
+	t.Run("Returns the access token on success", func(t *testing.T) {
+		client, server := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/authn-jwt/my-service/the-account/authenticate", r.URL.Path)
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "a-jwt", r.FormValue("jwt"))
+			fmt.Fprint(w, "the-access-token")
+		})
+		defer server.Close()
+
+		token, err := client.AuthenticateJWT(EnvJWTProvider{Var: "TEST_JWT"})
+		assert.Error(t, err) // env var isn't set; JWT() itself should fail first
+		assert.Nil(t, token)
+
+		os.Setenv("TEST_JWT", "a-jwt")
+		defer os.Unsetenv("TEST_JWT")
+
+		token, err = client.AuthenticateJWT(EnvJWTProvider{Var: "TEST_JWT"})
+		assert.NoError(t, err)
+		assert.Equal(t, "the-access-token", string(token))
+	})
+
+	t.Run("Includes the host-id segment when JWTHostID is set", func(t *testing.T) {
+		client, server := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/authn-jwt/my-service/the-account/host/my-host/authenticate", r.URL.Path)
+			fmt.Fprint(w, "the-access-token")
+		})
+		defer server.Close()
+		client.config.JWTHostID = "my-host"
+
+		_, err := client.AuthenticateJWT(EnvJWTProvider{Var: "TEST_JWT_2"})
+		assert.Error(t, err)
+
+		os.Setenv("TEST_JWT_2", "a-jwt")
+		defer os.Unsetenv("TEST_JWT_2")
+
+		_, err = client.AuthenticateJWT(EnvJWTProvider{Var: "TEST_JWT_2"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Returns an error when AuthnType is not jwt", func(t *testing.T) {
+		client := &Client{config: Config{AuthnType: AuthnTypeLDAP}}
+		_, err := client.AuthenticateJWT(EnvJWTProvider{Var: "TEST_JWT"})
+		assert.Error(t, err)
+	})
+}
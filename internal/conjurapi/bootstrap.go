@@ -0,0 +1,157 @@
+package conjurapi
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+)
+
+// BootstrapOptions configures Config.Bootstrap, the programmatic form of
+// `conjur configure`.
+type BootstrapOptions struct {
+	ApplianceURL string
+	Account      string
+	AuthnType    string
+	ServiceID    string
+	Login        string
+	Password     string
+
+	// ConjurrcPath defaults to $HOME/.conjurrc.
+	ConjurrcPath string
+	// Force allows overwriting an existing conjurrc at ConjurrcPath.
+	Force bool
+
+	// ConfirmFingerprint is consulted with the appliance's pinned
+	// certificate fingerprint before it is trusted. Returning false aborts
+	// the bootstrap. A nil ConfirmFingerprint auto-approves, for
+	// non-interactive callers that have already pinned the fingerprint out
+	// of band.
+	ConfirmFingerprint func(fingerprint string) bool
+}
+
+// Bootstrap performs the steps of `conjur configure`: pin the appliance's
+// TLS certificate into c.SSLCert, write a .conjurrc, log in once to obtain
+// an API key, and persist that key with c.CredentialStorage (a netrc at
+// c.NetRCPath by default). It refuses to overwrite an existing conjurrc
+// unless Force is set; re-running it with Force and the same options
+// reaches the same end state. Force does not apply to the netrc: an
+// existing netrc entry for the same machine with a different login is
+// never overwritten.
+func (c *Config) Bootstrap(opts BootstrapOptions) error {
+	conjurrcPath := opts.ConjurrcPath
+	if conjurrcPath == "" {
+		conjurrcPath = defaultConjurrcPath()
+	}
+	if conjurrcPath == "" {
+		return fmt.Errorf("Unable to determine a default conjurrc path; set BootstrapOptions.ConjurrcPath")
+	}
+
+	if !opts.Force {
+		if _, err := os.Stat(conjurrcPath); err == nil {
+			return fmt.Errorf("%s already exists, use --force to overwrite", conjurrcPath)
+		}
+	}
+
+	fingerprint, certPEM, err := fetchApplianceCertFingerprint(opts.ApplianceURL)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve the appliance certificate: %s", err)
+	}
+	if opts.ConfirmFingerprint != nil && !opts.ConfirmFingerprint(fingerprint) {
+		return fmt.Errorf("Appliance certificate fingerprint %s was not confirmed", fingerprint)
+	}
+
+	c.Account = opts.Account
+	c.ApplianceURL = opts.ApplianceURL
+	c.AuthnType = opts.AuthnType
+	c.ServiceID = opts.ServiceID
+	c.SSLCert = string(certPEM)
+	if c.NetRCPath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			c.NetRCPath = path.Join(home, ".netrc")
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(conjurrcPath, c.Conjurrc(), 0644); err != nil {
+		return fmt.Errorf("Unable to write %s: %s", conjurrcPath, err)
+	}
+
+	client, err := NewClientFromConfig(*c)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := client.Login(opts.Login, opts.Password)
+	if err != nil {
+		return fmt.Errorf("Unable to log in to fetch an API key: %s", err)
+	}
+
+	machine, err := machineFromApplianceURL(opts.ApplianceURL)
+	if err != nil {
+		return err
+	}
+
+	storage, err := c.CredentialStorage()
+	if err != nil {
+		return err
+	}
+	if err := storage.Store(machine, opts.Login, apiKey); err != nil {
+		return fmt.Errorf("Unable to store the API key for %s: %s", machine, err)
+	}
+
+	return nil
+}
+
+// fetchApplianceCertFingerprint dials applianceURL over TLS and returns the
+// SHA-256 fingerprint of its leaf certificate, along with the certificate
+// itself PEM-encoded so it can be pinned into Config.SSLCert.
+func fetchApplianceCertFingerprint(applianceURL string) (fingerprint string, certPEM []byte, err error) {
+	u, err := url.Parse(applianceURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("Invalid appliance URL: %s", err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = host + ":443"
+	}
+
+	conn, err := tls.Dial("tcp", host, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", nil, fmt.Errorf("Appliance presented no certificate")
+	}
+	leaf := certs[0]
+
+	sum := sha256.Sum256(leaf.Raw)
+	fingerprint = hex.EncodeToString(sum[:])
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+
+	return fingerprint, certPEM, nil
+}
+
+func machineFromApplianceURL(applianceURL string) (string, error) {
+	u, err := url.Parse(applianceURL)
+	if err != nil {
+		return "", fmt.Errorf("Invalid appliance URL: %s", err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("Appliance URL %q has no host", applianceURL)
+	}
+	return u.Hostname(), nil
+}
@@ -0,0 +1,125 @@
+package conjurapi
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachineFromApplianceURL(t *testing.T) {
+	t.Run("Extracts the host from a well-formed URL", func(t *testing.T) {
+		machine, err := machineFromApplianceURL("https://conjur.example.com/api")
+		assert.NoError(t, err)
+		assert.Equal(t, "conjur.example.com", machine)
+	})
+
+	t.Run("Returns an error for an unparseable URL", func(t *testing.T) {
+		_, err := machineFromApplianceURL("://bad-url")
+		assert.Error(t, err)
+	})
+
+	t.Run("Returns an error for a URL with no host", func(t *testing.T) {
+		_, err := machineFromApplianceURL("not-a-url")
+		assert.Error(t, err)
+	})
+}
+
+func TestFetchApplianceCertFingerprint(t *testing.T) {
+	t.Run("Pins the fingerprint of the server's leaf certificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		fingerprint, certPEM, err := fetchApplianceCertFingerprint(server.URL)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, fingerprint)
+
+		block, _ := pem.Decode(certPEM)
+		assert.NotNil(t, block)
+		assert.Equal(t, "CERTIFICATE", block.Type)
+	})
+}
+
+func TestConfig_Bootstrap(t *testing.T) {
+	newServer := func(apiKey string) *httptest.Server {
+		return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/authn/the-account/login" {
+				fmt.Fprint(w, apiKey)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+	}
+
+	t.Run("Writes a conjurrc and a netrc entry", func(t *testing.T) {
+		server := newServer("the-api-key")
+		defer server.Close()
+
+		dir := t.TempDir()
+		conjurrcPath := filepath.Join(dir, ".conjurrc")
+		netrcPath := filepath.Join(dir, ".netrc")
+
+		config := &Config{NetRCPath: netrcPath}
+		err := config.Bootstrap(BootstrapOptions{
+			ApplianceURL: server.URL,
+			Account:      "the-account",
+			Login:        "the-login",
+			Password:     "the-password",
+			ConjurrcPath: conjurrcPath,
+		})
+		assert.NoError(t, err)
+
+		conjurrcContents, err := os.ReadFile(conjurrcPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(conjurrcContents), "account: the-account")
+
+		netrcContents, err := os.ReadFile(netrcPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(netrcContents), "the-login")
+		assert.Contains(t, string(netrcContents), "the-api-key")
+	})
+
+	t.Run("Refuses to overwrite an existing conjurrc without Force", func(t *testing.T) {
+		server := newServer("the-api-key")
+		defer server.Close()
+
+		dir := t.TempDir()
+		conjurrcPath := filepath.Join(dir, ".conjurrc")
+		assert.NoError(t, os.WriteFile(conjurrcPath, []byte("account: existing\n"), 0644))
+
+		config := &Config{NetRCPath: filepath.Join(dir, ".netrc")}
+		err := config.Bootstrap(BootstrapOptions{
+			ApplianceURL: server.URL,
+			Account:      "the-account",
+			Login:        "the-login",
+			Password:     "the-password",
+			ConjurrcPath: conjurrcPath,
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("Aborts when the fingerprint is not confirmed", func(t *testing.T) {
+		server := newServer("the-api-key")
+		defer server.Close()
+
+		dir := t.TempDir()
+
+		config := &Config{NetRCPath: filepath.Join(dir, ".netrc")}
+		err := config.Bootstrap(BootstrapOptions{
+			ApplianceURL:       server.URL,
+			Account:            "the-account",
+			Login:              "the-login",
+			Password:           "the-password",
+			ConjurrcPath:       filepath.Join(dir, ".conjurrc"),
+			ConfirmFingerprint: func(string) bool { return false },
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not confirmed")
+	})
+}
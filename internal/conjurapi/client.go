@@ -0,0 +1,76 @@
+package conjurapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Client is a thin wrapper around an http.Client configured to talk to a
+// single Conjur appliance, as described by a Config.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClientFromConfig validates config and builds a Client ready to make
+// requests against config.ApplianceURL.
+func NewClientFromConfig(config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	httpClient, err := httpClientFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{config: config, httpClient: httpClient}, nil
+}
+
+// httpClientFromConfig builds the *http.Client used to reach the Conjur
+// appliance, wiring up mutual TLS when the Config carries a client
+// certificate (either because AuthnType is "cert", or because the
+// appliance itself requires client certs at the TLS layer).
+func httpClientFromConfig(config Config) (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+
+	if config.IsHttps() {
+		caPEM := []byte(config.SSLCert)
+		if config.SSLCert == "" {
+			data, err := ioutil.ReadFile(config.SSLCertPath)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to read SSLCertPath %s: %s", config.SSLCertPath, err)
+			}
+			caPEM = data
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("Unable to parse appliance CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCert != "" || config.ClientCertPath != "" {
+		cert, err := config.ClientTLSCertificate()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+// applianceURL builds an absolute URL under the Client's configured
+// appliance for the given path.
+func (c *Client) applianceURL(pathFormat string, args ...interface{}) string {
+	return fmt.Sprintf(c.config.ApplianceURL+pathFormat, args...)
+}
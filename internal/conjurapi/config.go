@@ -0,0 +1,416 @@
+// Package conjurapi provides a client for the Conjur HTTP API, along with
+// the configuration plumbing needed to locate and authenticate against a
+// Conjur (or Conjur Cloud) appliance.
+package conjurapi
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AuthnType identifies which Conjur authenticator a Config should use to
+// obtain an access token.
+const (
+	AuthnTypeDefault = ""
+	AuthnTypeLDAP    = "ldap"
+	AuthnTypeOIDC    = "oidc"
+	AuthnTypeCert    = "cert"
+	AuthnTypeJWT     = "jwt"
+)
+
+// authnTypesRequiringServiceID are the AuthnType values for which
+// Config.ServiceID must be set.
+var authnTypesRequiringServiceID = map[string]bool{
+	AuthnTypeLDAP: true,
+	AuthnTypeOIDC: true,
+	AuthnTypeCert: true,
+	AuthnTypeJWT:  true,
+}
+
+// ValidAuthnTypes is the complete set of AuthnType values Validate accepts.
+var ValidAuthnTypes = []string{AuthnTypeLDAP, AuthnTypeOIDC, AuthnTypeCert, AuthnTypeJWT}
+
+// Config defines the configuration parameters needed for a Conjur client
+// to talk to a Conjur endpoint.
+type Config struct {
+	Account      string
+	ApplianceURL string
+	NetRCPath    string
+	SSLCert      string
+	SSLCertPath  string
+
+	// AuthnType selects an alternate authenticator (e.g. "ldap", "oidc",
+	// "cert"). Leave blank to use Conjur's built-in authn.
+	AuthnType string
+	// ServiceID identifies the authenticator's service-id segment, e.g.
+	// /authn-ldap/{service-id}/.... Required when AuthnType is set to an
+	// authenticator that is deployed per-service-id.
+	ServiceID string
+
+	// ClientCert and ClientKey hold a PEM-encoded client certificate and
+	// private key (as in-memory content) used for mutual TLS, either to
+	// authenticate via AuthnTypeCert or simply to satisfy an appliance that
+	// requires client certs at the TLS layer. ClientCertPath and
+	// ClientKeyPath are the on-disk equivalents; if both a value and a path
+	// are given, the value wins.
+	ClientCert     string
+	ClientKey      string
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// JWTHostID, JWTTokenPath, and JWTToken configure the jwt authenticator.
+	// JWTToken is the token itself; JWTTokenPath points to a file holding
+	// it (e.g. a GitLab CI CI_JOB_JWT file, or a Kubernetes projected
+	// volume). Validate requires exactly one of JWTToken or JWTTokenPath
+	// when AuthnType is "jwt". Client.AuthenticateJWT does not read these
+	// fields: it uses the JWTProvider passed to it, which Config.JWTProvider
+	// can build from them.
+	JWTHostID    string
+	JWTTokenPath string
+	JWTToken     string
+
+	// CredentialStorageType selects how the API key Login obtains is
+	// persisted: "netrc" (the default), "keyring", "file", or "memory".
+	// See CredentialStorage.
+	CredentialStorageType string
+	// CredentialFilePath is where CredentialStorageType "file" persists
+	// its encrypted credentials.
+	CredentialFilePath string
+
+	// sources records, for fields populated via LoadConfigWithOptions, which
+	// file or environment variable supplied each one. It is left nil by
+	// mergeEnv/mergeYAML/LoadConfig so their behavior and tests are
+	// unaffected; only LoadConfigWithOptions populates it.
+	sources map[string]string
+}
+
+// Sources returns the origin (a file path, or "env:VAR_NAME") of each
+// Config field that was populated by LoadConfigWithOptions, keyed by field
+// name. It is empty for a Config built any other way.
+func (c *Config) Sources() map[string]string {
+	sources := make(map[string]string, len(c.sources))
+	for k, v := range c.sources {
+		sources[k] = v
+	}
+	return sources
+}
+
+// ConjurrcFields mirrors the on-disk .conjurrc YAML schema. Field order here
+// determines the order fields are emitted in by Conjurrc.
+type ConjurrcFields struct {
+	Account               string `yaml:"account,omitempty"`
+	ApplianceURL          string `yaml:"appliance_url,omitempty"`
+	NetRCPath             string `yaml:"netrc_path,omitempty"`
+	SSLCertPath           string `yaml:"cert_file,omitempty"`
+	AuthnType             string `yaml:"authn_type,omitempty"`
+	ServiceID             string `yaml:"service_id,omitempty"`
+	ClientCertPath        string `yaml:"client_cert_file,omitempty"`
+	ClientKeyPath         string `yaml:"client_key_file,omitempty"`
+	JWTTokenPath          string `yaml:"jwt_token_path,omitempty"`
+	JWTHostID             string `yaml:"jwt_host_id,omitempty"`
+	CredentialStorageType string `yaml:"credential_storage,omitempty"`
+	CredentialFilePath    string `yaml:"credential_file_path,omitempty"`
+}
+
+// IsHttps returns true when the Config carries a CA certificate (inline or
+// on disk), indicating the appliance should be reached over HTTPS.
+func (c *Config) IsHttps() bool {
+	return c.SSLCert != "" || c.SSLCertPath != ""
+}
+
+// Validate checks that the Config is complete enough to build a client,
+// returning every problem found joined into a single error (via
+// errors.Join), so callers can errors.Is/errors.As against any one of them
+// regardless of what else was wrong.
+func (c *Config) Validate() error {
+	var problems []error
+
+	if c.ApplianceURL == "" {
+		problems = append(problems, ErrMissingApplianceURL)
+	}
+
+	if c.AuthnType != "" && !isValidAuthnType(c.AuthnType) {
+		problems = append(problems, ErrUnsupportedAuthnType{Got: c.AuthnType, Allowed: ValidAuthnTypes})
+	}
+
+	if authnTypesRequiringServiceID[c.AuthnType] && c.ServiceID == "" {
+		problems = append(problems, ErrMissingServiceID{AuthnType: c.AuthnType})
+	}
+
+	if c.AuthnType == AuthnTypeCert {
+		if c.ClientCert == "" && c.ClientCertPath == "" {
+			problems = append(problems, fmt.Errorf("Must specify a ClientCert or ClientCertPath when using cert authentication"))
+		}
+		if c.ClientKey == "" && c.ClientKeyPath == "" {
+			problems = append(problems, fmt.Errorf("Must specify a ClientKey or ClientKeyPath when using cert authentication"))
+		}
+	}
+
+	if c.AuthnType == AuthnTypeJWT {
+		switch {
+		case c.JWTToken == "" && c.JWTTokenPath == "":
+			problems = append(problems, fmt.Errorf("Must specify exactly one of JWTToken or JWTTokenPath when using jwt authentication"))
+		case c.JWTToken != "" && c.JWTTokenPath != "":
+			problems = append(problems, fmt.Errorf("Must specify only one of JWTToken or JWTTokenPath when using jwt authentication"))
+		}
+	}
+
+	if c.CredentialStorageType != "" && !isValidCredentialStorageType(c.CredentialStorageType) {
+		problems = append(problems, fmt.Errorf("CredentialStorageType must be one of %s", strings.Join(ValidCredentialStorageTypes, ", ")))
+	}
+
+	if c.CredentialStorageType == CredentialStorageFile && c.CredentialFilePath == "" {
+		problems = append(problems, fmt.Errorf("Must specify a CredentialFilePath when using file credential storage"))
+	}
+
+	return errors.Join(problems...)
+}
+
+func isValidAuthnType(authnType string) bool {
+	for _, allowed := range ValidAuthnTypes {
+		if authnType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientTLSCertificate loads the configured client certificate and key
+// (preferring inline values over on-disk paths) and returns a parsed
+// tls.Certificate suitable for tls.Config.Certificates, for use when the
+// Conjur appliance requires mutual TLS.
+func (c *Config) ClientTLSCertificate() (tls.Certificate, error) {
+	certPEM, err := c.loadClientCertPEM()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	keyPEM, err := c.loadClientKeyPEM()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("Unable to parse client certificate/key: %s", err)
+	}
+
+	return cert, nil
+}
+
+func (c *Config) loadClientCertPEM() ([]byte, error) {
+	if c.ClientCert != "" {
+		return []byte(c.ClientCert), nil
+	}
+	data, err := ioutil.ReadFile(c.ClientCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read ClientCertPath %s: %s", c.ClientCertPath, err)
+	}
+	return data, nil
+}
+
+func (c *Config) loadClientKeyPEM() ([]byte, error) {
+	if c.ClientKey != "" {
+		return []byte(c.ClientKey), nil
+	}
+	data, err := ioutil.ReadFile(c.ClientKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read ClientKeyPath %s: %s", c.ClientKeyPath, err)
+	}
+	return data, nil
+}
+
+// mergeEnv overlays configuration read from the environment onto c,
+// leaving any field without a corresponding env var untouched.
+func (c *Config) mergeEnv() {
+	if v := os.Getenv("CONJUR_ACCOUNT"); v != "" {
+		c.Account = v
+	}
+	if v := os.Getenv("CONJUR_APPLIANCE_URL"); v != "" {
+		c.ApplianceURL = v
+	}
+	if v := os.Getenv("CONJUR_NETRC_PATH"); v != "" {
+		c.NetRCPath = v
+	}
+	if v := os.Getenv("CONJUR_CERT_FILE"); v != "" {
+		c.SSLCertPath = v
+	}
+	if v := os.Getenv("CONJUR_SSL_CERTIFICATE"); v != "" {
+		c.SSLCert = v
+	}
+	if v := os.Getenv("CONJUR_AUTHN_TYPE"); v != "" {
+		c.AuthnType = v
+	}
+	if v := os.Getenv("CONJUR_SERVICE_ID"); v != "" {
+		c.ServiceID = v
+	}
+	if v := os.Getenv("CONJUR_CLIENT_CERT"); v != "" {
+		c.ClientCert = v
+	}
+	if v := os.Getenv("CONJUR_CLIENT_KEY"); v != "" {
+		c.ClientKey = v
+	}
+	if v := os.Getenv("CONJUR_CLIENT_CERT_PATH"); v != "" {
+		c.ClientCertPath = v
+	}
+	if v := os.Getenv("CONJUR_CLIENT_KEY_PATH"); v != "" {
+		c.ClientKeyPath = v
+	}
+	if v := os.Getenv("CONJUR_AUTHN_JWT_TOKEN"); v != "" {
+		c.JWTToken = v
+	}
+	if v := os.Getenv("CONJUR_AUTHN_JWT_TOKEN_PATH"); v != "" {
+		c.JWTTokenPath = v
+	}
+	if v := os.Getenv("CONJUR_AUTHN_JWT_HOST_ID"); v != "" {
+		c.JWTHostID = v
+	}
+	if v := os.Getenv("CONJUR_CREDENTIAL_STORAGE"); v != "" {
+		c.CredentialStorageType = v
+	}
+	if v := os.Getenv("CONJUR_CREDENTIAL_FILE_PATH"); v != "" {
+		c.CredentialFilePath = v
+	}
+}
+
+// mergeYAML reads a .conjurrc file at filepath and overlays any fields it
+// sets onto c. Fields that are left blank in the file are left untouched
+// on c, so mergeYAML can be called repeatedly against multiple candidate
+// files to build up a merged Config.
+func (c *Config) mergeYAML(filepath string) error {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return err
+	}
+
+	var fields ConjurrcFields
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return ErrConjurrcParse{Path: filepath, Cause: err}
+	}
+
+	if fields.Account != "" {
+		c.Account = fields.Account
+	}
+	if fields.ApplianceURL != "" {
+		c.ApplianceURL = fields.ApplianceURL
+	}
+	if fields.NetRCPath != "" {
+		c.NetRCPath = fields.NetRCPath
+	}
+	if fields.SSLCertPath != "" {
+		c.SSLCertPath = fields.SSLCertPath
+	}
+	if fields.AuthnType != "" {
+		c.AuthnType = fields.AuthnType
+	}
+	if fields.ServiceID != "" {
+		c.ServiceID = fields.ServiceID
+	}
+	if fields.ClientCertPath != "" {
+		c.ClientCertPath = fields.ClientCertPath
+	}
+	if fields.ClientKeyPath != "" {
+		c.ClientKeyPath = fields.ClientKeyPath
+	}
+	if fields.JWTTokenPath != "" {
+		c.JWTTokenPath = fields.JWTTokenPath
+	}
+	if fields.JWTHostID != "" {
+		c.JWTHostID = fields.JWTHostID
+	}
+	if fields.CredentialStorageType != "" {
+		c.CredentialStorageType = fields.CredentialStorageType
+	}
+	if fields.CredentialFilePath != "" {
+		c.CredentialFilePath = fields.CredentialFilePath
+	}
+
+	return nil
+}
+
+// Conjurrc serializes c into the YAML content of a .conjurrc file.
+func (c *Config) Conjurrc() []byte {
+	fields := ConjurrcFields{
+		Account:               c.Account,
+		ApplianceURL:          c.ApplianceURL,
+		NetRCPath:             c.NetRCPath,
+		SSLCertPath:           c.SSLCertPath,
+		AuthnType:             c.AuthnType,
+		ServiceID:             c.ServiceID,
+		ClientCertPath:        c.ClientCertPath,
+		ClientKeyPath:         c.ClientKeyPath,
+		JWTTokenPath:          c.JWTTokenPath,
+		JWTHostID:             c.JWTHostID,
+		CredentialStorageType: c.CredentialStorageType,
+		CredentialFilePath:    c.CredentialFilePath,
+	}
+
+	// yaml.Marshal on a struct never errors.
+	out, _ := yaml.Marshal(fields)
+	return out
+}
+
+// defaultConjurrcPath returns $HOME/.conjurrc, the conventional location
+// LoadConfig looks for a conjurrc file when none is given explicitly.
+func defaultConjurrcPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return path.Join(home, ".conjurrc")
+}
+
+// LoadConfig builds a Config by merging, in increasing precedence, the
+// default conjurrc file and the process environment.
+func LoadConfig() (Config, error) {
+	config := Config{}
+
+	if conjurrcPath := defaultConjurrcPath(); conjurrcPath != "" {
+		if err := config.mergeYAML(conjurrcPath); err != nil && !os.IsNotExist(err) {
+			return config, err
+		}
+	}
+
+	config.mergeEnv()
+
+	if config.NetRCPath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			config.NetRCPath = path.Join(home, ".netrc")
+		}
+	}
+
+	return config, nil
+}
+
+// EnvSnapshot is a captured copy of the process environment, as returned
+// by ClearEnv.
+type EnvSnapshot map[string]string
+
+// ClearEnv wipes the process environment and returns a snapshot of what it
+// contained, so tests can exercise env-based configuration in isolation
+// and then restore the original environment with RestoreEnv.
+func ClearEnv() EnvSnapshot {
+	snapshot := EnvSnapshot{}
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		snapshot[parts[0]] = parts[1]
+	}
+	os.Clearenv()
+	return snapshot
+}
+
+// RestoreEnv clears the process environment and repopulates it from the
+// snapshot taken by ClearEnv.
+func (e EnvSnapshot) RestoreEnv() {
+	os.Clearenv()
+	for k, v := range e {
+		os.Setenv(k, v)
+	}
+}
@@ -44,9 +44,7 @@ func TestConfig_IsValid(t *testing.T) {
 
 		err := config.Validate()
 		assert.Error(t, err)
-
-		errString := err.Error()
-		assert.Contains(t, errString, "Must specify an ApplianceURL")
+		assert.ErrorIs(t, err, ErrMissingApplianceURL)
 	})
 
 	t.Run("Return error for invalid configuration missing ServiceId", func(t *testing.T) {
@@ -59,8 +57,9 @@ func TestConfig_IsValid(t *testing.T) {
 		err := config.Validate()
 		assert.Error(t, err)
 
-		errString := err.Error()
-		assert.Contains(t, errString, "Must specify a ServiceID when using ")
+		var missingServiceID ErrMissingServiceID
+		assert.ErrorAs(t, err, &missingServiceID)
+		assert.Equal(t, "ldap", missingServiceID.AuthnType)
 	})
 
 	t.Run("Return error for invalid configuration unsupported AuthnType", func(t *testing.T) {
@@ -74,8 +73,116 @@ func TestConfig_IsValid(t *testing.T) {
 		err := config.Validate()
 		assert.Error(t, err)
 
+		var unsupported ErrUnsupportedAuthnType
+		assert.ErrorAs(t, err, &unsupported)
+		assert.Equal(t, "foobar", unsupported.Got)
+		assert.Equal(t, ValidAuthnTypes, unsupported.Allowed)
+	})
+
+	t.Run("Return error for cert configuration missing ClientCert and ClientKey", func(t *testing.T) {
+		config := Config{
+			Account:      "account",
+			ApplianceURL: "appliance-url",
+			AuthnType:    "cert",
+			ServiceID:    "service-id",
+		}
+
+		err := config.Validate()
+		assert.Error(t, err)
+
 		errString := err.Error()
-		assert.Contains(t, errString, "AuthnType must be one of ")
+		assert.Contains(t, errString, "Must specify a ClientCert or ClientCertPath")
+		assert.Contains(t, errString, "Must specify a ClientKey or ClientKeyPath")
+	})
+
+	t.Run("Return without error for cert configuration with ClientCertPath and ClientKeyPath", func(t *testing.T) {
+		config := Config{
+			Account:        "account",
+			ApplianceURL:   "appliance-url",
+			AuthnType:      "cert",
+			ServiceID:      "service-id",
+			ClientCertPath: "/path/to/cert.pem",
+			ClientKeyPath:  "/path/to/key.pem",
+		}
+
+		err := config.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Return error for unsupported CredentialStorageType", func(t *testing.T) {
+		config := Config{
+			Account:               "account",
+			ApplianceURL:          "appliance-url",
+			CredentialStorageType: "carrier-pigeon",
+		}
+
+		err := config.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CredentialStorageType must be one of ")
+	})
+
+	t.Run("Return without error for keyring CredentialStorageType without a NetRCPath", func(t *testing.T) {
+		config := Config{
+			Account:               "account",
+			ApplianceURL:          "appliance-url",
+			CredentialStorageType: "keyring",
+		}
+
+		err := config.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Return error for file CredentialStorageType missing a CredentialFilePath", func(t *testing.T) {
+		config := Config{
+			Account:               "account",
+			ApplianceURL:          "appliance-url",
+			CredentialStorageType: "file",
+		}
+
+		err := config.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Must specify a CredentialFilePath")
+	})
+
+	t.Run("Return error for jwt configuration missing a token source", func(t *testing.T) {
+		config := Config{
+			Account:      "account",
+			ApplianceURL: "appliance-url",
+			AuthnType:    "jwt",
+			ServiceID:    "service-id",
+		}
+
+		err := config.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Must specify exactly one of JWTToken or JWTTokenPath")
+	})
+
+	t.Run("Return error for jwt configuration with both token sources", func(t *testing.T) {
+		config := Config{
+			Account:      "account",
+			ApplianceURL: "appliance-url",
+			AuthnType:    "jwt",
+			ServiceID:    "service-id",
+			JWTToken:     "a-token",
+			JWTTokenPath: "/path/to/token",
+		}
+
+		err := config.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Must specify only one of JWTToken or JWTTokenPath")
+	})
+
+	t.Run("Return without error for jwt configuration with JWTTokenPath", func(t *testing.T) {
+		config := Config{
+			Account:      "account",
+			ApplianceURL: "appliance-url",
+			AuthnType:    "jwt",
+			ServiceID:    "service-id",
+			JWTTokenPath: "/path/to/token",
+		}
+
+		err := config.Validate()
+		assert.NoError(t, err)
 	})
 }
 
@@ -129,6 +236,64 @@ func TestConfig_LoadFromEnv(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("Given cert configuration in env", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		os.Setenv("CONJUR_ACCOUNT", "account")
+		os.Setenv("CONJUR_APPLIANCE_URL", "appliance-url")
+		os.Setenv("CONJUR_AUTHN_TYPE", "cert")
+		os.Setenv("CONJUR_SERVICE_ID", "service-id")
+		os.Setenv("CONJUR_CLIENT_CERT", "cert-contents")
+		os.Setenv("CONJUR_CLIENT_KEY", "key-contents")
+		os.Setenv("CONJUR_CLIENT_CERT_PATH", "/path/to/cert.pem")
+		os.Setenv("CONJUR_CLIENT_KEY_PATH", "/path/to/key.pem")
+
+		t.Run("Returns Config loaded with client cert values from env", func(t *testing.T) {
+			config := &Config{}
+			config.mergeEnv()
+
+			assert.EqualValues(t, *config, Config{
+				Account:        "account",
+				ApplianceURL:   "appliance-url",
+				AuthnType:      "cert",
+				ServiceID:      "service-id",
+				ClientCert:     "cert-contents",
+				ClientKey:      "key-contents",
+				ClientCertPath: "/path/to/cert.pem",
+				ClientKeyPath:  "/path/to/key.pem",
+			})
+		})
+	})
+
+	t.Run("Given jwt configuration in env", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		os.Setenv("CONJUR_ACCOUNT", "account")
+		os.Setenv("CONJUR_APPLIANCE_URL", "appliance-url")
+		os.Setenv("CONJUR_AUTHN_TYPE", "jwt")
+		os.Setenv("CONJUR_SERVICE_ID", "service-id")
+		os.Setenv("CONJUR_AUTHN_JWT_TOKEN", "a-token")
+		os.Setenv("CONJUR_AUTHN_JWT_TOKEN_PATH", "/path/to/token")
+		os.Setenv("CONJUR_AUTHN_JWT_HOST_ID", "host/my-host")
+
+		t.Run("Returns Config loaded with jwt values from env", func(t *testing.T) {
+			config := &Config{}
+			config.mergeEnv()
+
+			assert.EqualValues(t, *config, Config{
+				Account:      "account",
+				ApplianceURL: "appliance-url",
+				AuthnType:    "jwt",
+				ServiceID:    "service-id",
+				JWTToken:     "a-token",
+				JWTTokenPath: "/path/to/token",
+				JWTHostID:    "host/my-host",
+			})
+		})
+	})
 }
 
 var versiontests = []struct {
@@ -198,6 +363,64 @@ service_id: my-ldap-service
 		})
 	}
 
+	t.Run("Given a conjurrc file with client cert authn", func(t *testing.T) {
+		conjurrcFileContents := `
+---
+appliance_url: http://path/to/appliance
+account: some account
+authn_type: cert
+service_id: my-cert-service
+client_cert_file: "/path/to/client/cert.pem"
+client_key_file: "/path/to/client/key.pem"
+`
+
+		tmpFileName, err := TempFileForTesting("TestConfigClientCert", conjurrcFileContents, t)
+		defer os.Remove(tmpFileName) // clean up
+		assert.NoError(t, err)
+
+		config := &Config{}
+		err = config.mergeYAML(tmpFileName)
+		assert.NoError(t, err)
+
+		assert.EqualValues(t, *config, Config{
+			Account:        "some account",
+			ApplianceURL:   "http://path/to/appliance",
+			AuthnType:      "cert",
+			ServiceID:      "my-cert-service",
+			ClientCertPath: "/path/to/client/cert.pem",
+			ClientKeyPath:  "/path/to/client/key.pem",
+		})
+	})
+
+	t.Run("Given a conjurrc file with jwt authn", func(t *testing.T) {
+		conjurrcFileContents := `
+---
+appliance_url: http://path/to/appliance
+account: some account
+authn_type: jwt
+service_id: my-jwt-service
+jwt_token_path: "/path/to/jwt/token"
+jwt_host_id: "host/my-host"
+`
+
+		tmpFileName, err := TempFileForTesting("TestConfigJWT", conjurrcFileContents, t)
+		defer os.Remove(tmpFileName) // clean up
+		assert.NoError(t, err)
+
+		config := &Config{}
+		err = config.mergeYAML(tmpFileName)
+		assert.NoError(t, err)
+
+		assert.EqualValues(t, *config, Config{
+			Account:      "some account",
+			ApplianceURL: "http://path/to/appliance",
+			AuthnType:    "jwt",
+			ServiceID:    "my-jwt-service",
+			JWTTokenPath: "/path/to/jwt/token",
+			JWTHostID:    "host/my-host",
+		})
+	})
+
 	t.Run("Throws errors when conjurrc is present but unparsable", func(t *testing.T) {
 		badConjurrc := `
 ---
@@ -213,6 +436,11 @@ cert_file: "C:\badly\escaped\path"
 		config := &Config{}
 		err = config.mergeYAML(tmpFileName)
 		assert.Error(t, err)
+
+		var parseErr ErrConjurrcParse
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, tmpFileName, parseErr.Path)
+		assert.Error(t, parseErr.Cause)
 	})
 }
 
@@ -248,6 +476,57 @@ netrc_path: test-netrc-path
 cert_file: test-cert-path
 authn_type: ldap
 service_id: test-service-id
+`,
+	},
+	{
+		name: "Cert auth config",
+		config: Config{
+			Account:        "test-account",
+			ApplianceURL:   "test-appliance-url",
+			AuthnType:      "cert",
+			ServiceID:      "test-service-id",
+			ClientCertPath: "test-client-cert-path",
+			ClientKeyPath:  "test-client-key-path",
+		},
+		expected: `account: test-account
+appliance_url: test-appliance-url
+authn_type: cert
+service_id: test-service-id
+client_cert_file: test-client-cert-path
+client_key_file: test-client-key-path
+`,
+	},
+	{
+		name: "JWT auth config",
+		config: Config{
+			Account:      "test-account",
+			ApplianceURL: "test-appliance-url",
+			AuthnType:    "jwt",
+			ServiceID:    "test-service-id",
+			JWTTokenPath: "test-jwt-token-path",
+			JWTHostID:    "host/test-host",
+			JWTToken:     "should-not-be-persisted",
+		},
+		expected: `account: test-account
+appliance_url: test-appliance-url
+authn_type: jwt
+service_id: test-service-id
+jwt_token_path: test-jwt-token-path
+jwt_host_id: host/test-host
+`,
+	},
+	{
+		name: "File credential storage config",
+		config: Config{
+			Account:               "test-account",
+			ApplianceURL:          "test-appliance-url",
+			CredentialStorageType: "file",
+			CredentialFilePath:    "test-credential-file-path",
+		},
+		expected: `account: test-account
+appliance_url: test-appliance-url
+credential_storage: file
+credential_file_path: test-credential-file-path
 `,
 	},
 }
@@ -262,3 +541,25 @@ func TestConfig_Conjurrc(t *testing.T) {
 		}
 	})
 }
+
+func TestConfig_ClientTLSCertificate(t *testing.T) {
+	t.Run("Returns error when ClientCertPath cannot be read", func(t *testing.T) {
+		config := Config{
+			ClientCertPath: "/path/does/not/exist.pem",
+			ClientKeyPath:  "/path/does/not/exist.key",
+		}
+
+		_, err := config.ClientTLSCertificate()
+		assert.Error(t, err)
+	})
+
+	t.Run("Returns error when ClientCert is not a valid PEM key pair", func(t *testing.T) {
+		config := Config{
+			ClientCert: "not a certificate",
+			ClientKey:  "not a key",
+		}
+
+		_, err := config.ClientTLSCertificate()
+		assert.Error(t, err)
+	})
+}
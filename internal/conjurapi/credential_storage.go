@@ -0,0 +1,196 @@
+package conjurapi
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Credential storage types accepted by Config.CredentialStorageType.
+const (
+	CredentialStorageNetRC   = "netrc"
+	CredentialStorageKeyring = "keyring"
+	CredentialStorageFile    = "file"
+	CredentialStorageMemory  = "memory"
+)
+
+// ValidCredentialStorageTypes is the complete set of CredentialStorageType
+// values Validate accepts.
+var ValidCredentialStorageTypes = []string{
+	CredentialStorageNetRC,
+	CredentialStorageKeyring,
+	CredentialStorageFile,
+	CredentialStorageMemory,
+}
+
+func isValidCredentialStorageType(storageType string) bool {
+	for _, allowed := range ValidCredentialStorageTypes {
+		if storageType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// CredentialStorage persists the API key Conjur issues a login in exchange
+// for its password, so it doesn't need to be re-entered on every run.
+// machine identifies the appliance the credential belongs to (conventionally
+// its hostname, as in netrc).
+type CredentialStorage interface {
+	Store(machine, login, apiKey string) error
+	Lookup(machine string) (login string, apiKey string, err error)
+	Delete(machine string) error
+}
+
+// NetRCCredentialStorage stores credentials in a netrc file, the default
+// and long-standing behavior of this package.
+type NetRCCredentialStorage struct {
+	Path string
+}
+
+// Store implements CredentialStorage.
+func (s NetRCCredentialStorage) Store(machine, login, apiKey string) error {
+	return writeNetRCEntry(s.Path, machine, login, apiKey)
+}
+
+// Lookup implements CredentialStorage.
+func (s NetRCCredentialStorage) Lookup(machine string) (string, string, error) {
+	n, err := netRCParseFile(s.Path)
+	if err != nil {
+		return "", "", fmt.Errorf("Unable to parse %s: %s", s.Path, err)
+	}
+
+	m := n.FindMachine(machine)
+	if m == nil {
+		return "", "", fmt.Errorf("No netrc entry for %s in %s", machine, s.Path)
+	}
+
+	return m.Login, m.Password, nil
+}
+
+// Delete implements CredentialStorage.
+func (s NetRCCredentialStorage) Delete(machine string) error {
+	n, err := netRCParseFile(s.Path)
+	if err != nil {
+		return fmt.Errorf("Unable to parse %s: %s", s.Path, err)
+	}
+
+	removeMachine(n, machine)
+
+	return netRCWriteFile(s.Path, n)
+}
+
+// KeyringCredentialStorage stores credentials in the OS-native credential
+// store (macOS Keychain, Windows Credential Manager, the Secret Service on
+// Linux), via go-keyring, keyed by machine.
+type KeyringCredentialStorage struct {
+	// Service namespaces entries in the OS keyring. Defaults to
+	// "conjurapi" when empty.
+	Service string
+}
+
+func (s KeyringCredentialStorage) service() string {
+	if s.Service != "" {
+		return s.Service
+	}
+	return "conjurapi"
+}
+
+// Store implements CredentialStorage. The login and apiKey are packed into
+// a single keyring entry since go-keyring only stores one secret per
+// service/user pair.
+func (s KeyringCredentialStorage) Store(machine, login, apiKey string) error {
+	return keyring.Set(s.service(), machine, login+"\n"+apiKey)
+}
+
+// Lookup implements CredentialStorage.
+func (s KeyringCredentialStorage) Lookup(machine string) (string, string, error) {
+	packed, err := keyring.Get(s.service(), machine)
+	if err != nil {
+		return "", "", fmt.Errorf("Unable to read keyring entry for %s: %s", machine, err)
+	}
+
+	login, apiKey, ok := splitPacked(packed)
+	if !ok {
+		return "", "", fmt.Errorf("Malformed keyring entry for %s", machine)
+	}
+
+	return login, apiKey, nil
+}
+
+// Delete implements CredentialStorage.
+func (s KeyringCredentialStorage) Delete(machine string) error {
+	return keyring.Delete(s.service(), machine)
+}
+
+func splitPacked(packed string) (login string, apiKey string, ok bool) {
+	for i := 0; i < len(packed); i++ {
+		if packed[i] == '\n' {
+			return packed[:i], packed[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// MemoryCredentialStorage keeps credentials only in process memory, for
+// ephemeral CI jobs that would rather not write an API key to disk at all.
+// Credentials do not survive the process exiting.
+type MemoryCredentialStorage struct {
+	mu      sync.Mutex
+	entries map[string][2]string
+}
+
+// Store implements CredentialStorage.
+func (s *MemoryCredentialStorage) Store(machine, login, apiKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries == nil {
+		s.entries = map[string][2]string{}
+	}
+	s.entries[machine] = [2]string{login, apiKey}
+
+	return nil
+}
+
+// Lookup implements CredentialStorage.
+func (s *MemoryCredentialStorage) Lookup(machine string) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[machine]
+	if !ok {
+		return "", "", fmt.Errorf("No in-memory entry for %s", machine)
+	}
+
+	return entry[0], entry[1], nil
+}
+
+// Delete implements CredentialStorage.
+func (s *MemoryCredentialStorage) Delete(machine string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, machine)
+
+	return nil
+}
+
+// CredentialStorage returns the CredentialStorage implied by
+// c.CredentialStorageType, defaulting to NetRCCredentialStorage at
+// c.NetRCPath when CredentialStorageType is blank.
+func (c *Config) CredentialStorage() (CredentialStorage, error) {
+	switch c.CredentialStorageType {
+	case "", CredentialStorageNetRC:
+		return NetRCCredentialStorage{Path: c.NetRCPath}, nil
+	case CredentialStorageKeyring:
+		return KeyringCredentialStorage{}, nil
+	case CredentialStorageFile:
+		return NewFileCredentialStorage(c.CredentialFilePath)
+	case CredentialStorageMemory:
+		return &MemoryCredentialStorage{}, nil
+	default:
+		return nil, fmt.Errorf("Unknown CredentialStorageType %q", c.CredentialStorageType)
+	}
+}
@@ -0,0 +1,130 @@
+package conjurapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+)
+
+func TestNetRCCredentialStorage(t *testing.T) {
+	t.Run("Stores, looks up, and deletes a credential", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".netrc")
+		storage := NetRCCredentialStorage{Path: path}
+
+		assert.NoError(t, storage.Store("conjur.example.com", "the-login", "the-api-key"))
+
+		login, apiKey, err := storage.Lookup("conjur.example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "the-login", login)
+		assert.Equal(t, "the-api-key", apiKey)
+
+		assert.NoError(t, storage.Delete("conjur.example.com"))
+
+		_, _, err = storage.Lookup("conjur.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("Refuses to overwrite a conflicting login", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".netrc")
+		storage := NetRCCredentialStorage{Path: path}
+
+		assert.NoError(t, storage.Store("conjur.example.com", "first-login", "first-key"))
+
+		err := storage.Store("conjur.example.com", "second-login", "second-key")
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryCredentialStorage(t *testing.T) {
+	t.Run("Stores, looks up, and deletes a credential", func(t *testing.T) {
+		storage := &MemoryCredentialStorage{}
+
+		assert.NoError(t, storage.Store("conjur.example.com", "the-login", "the-api-key"))
+
+		login, apiKey, err := storage.Lookup("conjur.example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "the-login", login)
+		assert.Equal(t, "the-api-key", apiKey)
+
+		assert.NoError(t, storage.Delete("conjur.example.com"))
+
+		_, _, err = storage.Lookup("conjur.example.com")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyringCredentialStorage(t *testing.T) {
+	keyring.MockInit()
+
+	t.Run("Stores, looks up, and deletes a credential", func(t *testing.T) {
+		storage := KeyringCredentialStorage{}
+
+		assert.NoError(t, storage.Store("conjur.example.com", "the-login", "the-api-key"))
+
+		login, apiKey, err := storage.Lookup("conjur.example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "the-login", login)
+		assert.Equal(t, "the-api-key", apiKey)
+
+		assert.NoError(t, storage.Delete("conjur.example.com"))
+
+		_, _, err = storage.Lookup("conjur.example.com")
+		assert.Error(t, err)
+	})
+}
+
+func TestFileCredentialStorage(t *testing.T) {
+	keyring.MockInit()
+
+	t.Run("Stores, looks up, and deletes a credential, encrypted at rest", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials")
+		storage, err := NewFileCredentialStorage(path)
+		assert.NoError(t, err)
+
+		assert.NoError(t, storage.Store("conjur.example.com", "the-login", "the-api-key"))
+
+		contents, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(contents), "the-api-key")
+
+		login, apiKey, err := storage.Lookup("conjur.example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "the-login", login)
+		assert.Equal(t, "the-api-key", apiKey)
+
+		assert.NoError(t, storage.Delete("conjur.example.com"))
+
+		_, _, err = storage.Lookup("conjur.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("Requires a CredentialFilePath", func(t *testing.T) {
+		_, err := NewFileCredentialStorage("")
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_CredentialStorage(t *testing.T) {
+	t.Run("Defaults to NetRCCredentialStorage", func(t *testing.T) {
+		config := Config{NetRCPath: "/path/to/netrc"}
+		storage, err := config.CredentialStorage()
+		assert.NoError(t, err)
+		assert.Equal(t, NetRCCredentialStorage{Path: "/path/to/netrc"}, storage)
+	})
+
+	t.Run("Returns MemoryCredentialStorage for memory", func(t *testing.T) {
+		config := Config{CredentialStorageType: CredentialStorageMemory}
+		storage, err := config.CredentialStorage()
+		assert.NoError(t, err)
+		assert.IsType(t, &MemoryCredentialStorage{}, storage)
+	})
+
+	t.Run("Returns an error for an unknown type", func(t *testing.T) {
+		config := Config{CredentialStorageType: "carrier-pigeon"}
+		_, err := config.CredentialStorage()
+		assert.Error(t, err)
+	})
+}
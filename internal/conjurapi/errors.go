@@ -0,0 +1,79 @@
+package conjurapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingApplianceURL is returned by Config.Validate when ApplianceURL
+// is blank.
+var ErrMissingApplianceURL = errors.New("Must specify an ApplianceURL")
+
+// ErrMissingServiceID is returned by Config.Validate when AuthnType
+// requires a ServiceID and none was given. Use errors.As to recover
+// AuthnType from a wrapping error.
+type ErrMissingServiceID struct {
+	AuthnType string
+}
+
+func (e ErrMissingServiceID) Error() string {
+	return fmt.Sprintf("Must specify a ServiceID when using %s authentication", e.AuthnType)
+}
+
+// Is reports whether target is an ErrMissingServiceID, regardless of
+// AuthnType, so callers can use errors.Is without knowing which
+// authenticator triggered it.
+func (e ErrMissingServiceID) Is(target error) bool {
+	_, ok := target.(ErrMissingServiceID)
+	return ok
+}
+
+// ErrUnsupportedAuthnType is returned by Config.Validate when AuthnType is
+// set to a value Validate doesn't recognize.
+type ErrUnsupportedAuthnType struct {
+	Got     string
+	Allowed []string
+}
+
+func (e ErrUnsupportedAuthnType) Error() string {
+	return fmt.Sprintf("AuthnType must be one of %s, got %q", strings.Join(e.Allowed, ", "), e.Got)
+}
+
+// Is reports whether target is an ErrUnsupportedAuthnType.
+func (e ErrUnsupportedAuthnType) Is(target error) bool {
+	_, ok := target.(ErrUnsupportedAuthnType)
+	return ok
+}
+
+// ErrConjurrcParse is returned when a conjurrc file exists but cannot be
+// parsed as YAML. Cause is the underlying parse error; use errors.Unwrap
+// or errors.As to inspect it.
+type ErrConjurrcParse struct {
+	Path  string
+	Cause error
+}
+
+func (e ErrConjurrcParse) Error() string {
+	return fmt.Sprintf("Unable to parse %s: %s", e.Path, e.Cause)
+}
+
+func (e ErrConjurrcParse) Unwrap() error {
+	return e.Cause
+}
+
+// ErrConjurrcNotFound is returned by LoadConfigWithOptions when
+// LoadOptions.ExplicitPath is set but no file exists there.
+type ErrConjurrcNotFound struct {
+	Path string
+}
+
+func (e ErrConjurrcNotFound) Error() string {
+	return fmt.Sprintf("Conjur config file not found at %s", e.Path)
+}
+
+// Is reports whether target is an ErrConjurrcNotFound.
+func (e ErrConjurrcNotFound) Is(target error) bool {
+	_, ok := target.(ErrConjurrcNotFound)
+	return ok
+}
@@ -0,0 +1,44 @@
+package conjurapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrMissingServiceID_Is(t *testing.T) {
+	t.Run("Matches regardless of AuthnType", func(t *testing.T) {
+		err := wrapErr(ErrMissingServiceID{AuthnType: "ldap"})
+		assert.True(t, errors.Is(err, ErrMissingServiceID{AuthnType: "oidc"}))
+	})
+}
+
+func TestErrUnsupportedAuthnType_Is(t *testing.T) {
+	t.Run("Matches regardless of Got/Allowed", func(t *testing.T) {
+		err := wrapErr(ErrUnsupportedAuthnType{Got: "foobar", Allowed: ValidAuthnTypes})
+		assert.True(t, errors.Is(err, ErrUnsupportedAuthnType{}))
+	})
+}
+
+func TestErrConjurrcParse_Unwrap(t *testing.T) {
+	t.Run("Unwraps to the underlying parse error", func(t *testing.T) {
+		cause := errors.New("yaml: bad indentation")
+		err := ErrConjurrcParse{Path: "/some/.conjurrc", Cause: cause}
+
+		assert.ErrorIs(t, err, cause)
+	})
+}
+
+func TestErrConjurrcNotFound_Is(t *testing.T) {
+	t.Run("Matches regardless of Path", func(t *testing.T) {
+		err := wrapErr(ErrConjurrcNotFound{Path: "/a/.conjurrc"})
+		assert.True(t, errors.Is(err, ErrConjurrcNotFound{Path: "/b/.conjurrc"}))
+	})
+}
+
+// wrapErr simulates a caller that has wrapped one of these errors in
+// additional context, the way errors.Join or fmt.Errorf("%w", ...) would.
+func wrapErr(err error) error {
+	return errors.Join(err)
+}
@@ -0,0 +1,191 @@
+package conjurapi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zalando/go-keyring"
+)
+
+// FileCredentialStorage persists credentials AES-256-GCM-encrypted at
+// Path. The encryption key is generated on first use and kept in the OS
+// keyring (see KeyringCredentialStorage), so the file on disk is useless
+// without access to that same keyring. Store, Lookup and Delete each read
+// the key from the keyring, so every process using the file needs keyring
+// access.
+type FileCredentialStorage struct {
+	Path string
+}
+
+// NewFileCredentialStorage validates path and returns a FileCredentialStorage
+// for it.
+func NewFileCredentialStorage(path string) (FileCredentialStorage, error) {
+	if path == "" {
+		return FileCredentialStorage{}, fmt.Errorf("Must specify a CredentialFilePath when using file credential storage")
+	}
+	return FileCredentialStorage{Path: path}, nil
+}
+
+type fileCredentialEntry struct {
+	Login  string `json:"login"`
+	APIKey string `json:"api_key"`
+}
+
+// Store implements CredentialStorage.
+func (s FileCredentialStorage) Store(machine, login, apiKey string) error {
+	entries, err := s.readAll()
+	if err != nil {
+		return err
+	}
+
+	entries[machine] = fileCredentialEntry{Login: login, APIKey: apiKey}
+
+	return s.writeAll(entries)
+}
+
+// Lookup implements CredentialStorage.
+func (s FileCredentialStorage) Lookup(machine string) (string, string, error) {
+	entries, err := s.readAll()
+	if err != nil {
+		return "", "", err
+	}
+
+	entry, ok := entries[machine]
+	if !ok {
+		return "", "", fmt.Errorf("No entry for %s in %s", machine, s.Path)
+	}
+
+	return entry.Login, entry.APIKey, nil
+}
+
+// Delete implements CredentialStorage.
+func (s FileCredentialStorage) Delete(machine string) error {
+	entries, err := s.readAll()
+	if err != nil {
+		return err
+	}
+
+	delete(entries, machine)
+
+	return s.writeAll(entries)
+}
+
+func (s FileCredentialStorage) readAll() (map[string]fileCredentialEntry, error) {
+	entries := map[string]fileCredentialEntry{}
+
+	ciphertext, err := os.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("Unable to read %s: %s", s.Path, err)
+	}
+
+	key, err := s.encryptionKey()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decrypt(key, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decrypt %s: %s", s.Path, err)
+	}
+
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("Unable to parse %s: %s", s.Path, err)
+	}
+
+	return entries, nil
+}
+
+func (s FileCredentialStorage) writeAll(entries map[string]fileCredentialEntry) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("Unable to serialize %s: %s", s.Path, err)
+	}
+
+	key, err := s.encryptionKey()
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := encrypt(key, plaintext)
+	if err != nil {
+		return fmt.Errorf("Unable to encrypt %s: %s", s.Path, err)
+	}
+
+	if err := os.WriteFile(s.Path, ciphertext, 0600); err != nil {
+		return fmt.Errorf("Unable to write %s: %s", s.Path, err)
+	}
+
+	return nil
+}
+
+func (s FileCredentialStorage) encryptionKey() ([]byte, error) {
+	service := "conjurapi-file-credential-storage"
+
+	encoded, err := keyring.Get(service, s.Path)
+	if err == nil {
+		return hex.DecodeString(encoded)
+	}
+	if !errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("Unable to read encryption key from keyring: %s", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("Unable to generate an encryption key: %s", err)
+	}
+
+	if err := keyring.Set(service, s.Path, hex.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("Unable to store the encryption key in the keyring: %s", err)
+	}
+
+	return key, nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, sealed, nil)
+}
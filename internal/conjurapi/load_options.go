@@ -0,0 +1,175 @@
+package conjurapi
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// LoadOptions controls how LoadConfigWithOptions locates and merges
+// conjurrc files, modeled on kubeconfig's loading precedence.
+type LoadOptions struct {
+	// ExplicitPath, if set, is the only file considered. It must exist, or
+	// LoadConfigWithOptions returns ErrConjurrcNotFound.
+	ExplicitPath string
+}
+
+// configPrecedence lists, in decreasing precedence, the conjurrc locations
+// LoadConfigWithOptions merges when no ExplicitPath is given: $CONJURRC (if
+// set), ./.conjurrc, $HOME/.conjurrc, then /etc/conjur.conf. Earlier
+// entries win over later ones; environment variables are applied last of
+// all and so win over every file.
+func configPrecedence() []string {
+	candidates := []string{os.Getenv("CONJURRC"), "./.conjurrc"}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, path.Join(home, ".conjurrc"))
+	}
+
+	return append(candidates, "/etc/conjur.conf")
+}
+
+// LoadConfigWithOptions builds a Config the same way LoadConfig does, but
+// gives the caller control over which conjurrc file(s) are read and
+// exposes the provenance of each field via Config.Sources.
+//
+// With no ExplicitPath, every file in configPrecedence that exists is
+// merged from the last entry to the first, so a file earlier in
+// configPrecedence overrides fields set by one later in it; the
+// environment is merged last and so always wins. Candidate paths are made
+// absolute before they are read, so Sources reports absolute paths for them. With ExplicitPath set,
+// only that file is read, and it is an error for it not to exist.
+func LoadConfigWithOptions(opts LoadOptions) (Config, error) {
+	config := Config{}
+
+	if opts.ExplicitPath != "" {
+		if _, err := os.Stat(opts.ExplicitPath); err != nil {
+			return config, ErrConjurrcNotFound{Path: opts.ExplicitPath}
+		}
+		if err := config.mergeYAMLTracked(opts.ExplicitPath); err != nil {
+			return config, err
+		}
+	} else {
+		// Merge lowest precedence first, so that each subsequent, higher
+		// precedence candidate overrides the fields it also sets.
+		candidates := configPrecedence()
+		for i := len(candidates) - 1; i >= 0; i-- {
+			candidate := candidates[i]
+			if candidate == "" {
+				continue
+			}
+			if abs, err := filepath.Abs(candidate); err == nil {
+				candidate = abs
+			}
+			if _, err := os.Stat(candidate); err != nil {
+				continue
+			}
+			if err := config.mergeYAMLTracked(candidate); err != nil {
+				return config, err
+			}
+		}
+	}
+
+	config.mergeEnvTracked()
+
+	if config.NetRCPath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			config.NetRCPath = path.Join(home, ".netrc")
+		}
+	}
+
+	return config, nil
+}
+
+// mergeYAMLTracked merges filepath like mergeYAML, then records filepath as
+// the source of whichever fields it changed.
+func (c *Config) mergeYAMLTracked(filepath string) error {
+	before := *c
+	if err := c.mergeYAML(filepath); err != nil {
+		return err
+	}
+	c.recordChangedSources(before, filepath)
+	return nil
+}
+
+// mergeEnvTracked merges environment variables like mergeEnv, then records
+// the specific variable that supplied each changed field.
+func (c *Config) mergeEnvTracked() {
+	bindings := []struct {
+		field *string
+		name  string
+		env   string
+	}{
+		{&c.Account, "Account", "CONJUR_ACCOUNT"},
+		{&c.ApplianceURL, "ApplianceURL", "CONJUR_APPLIANCE_URL"},
+		{&c.NetRCPath, "NetRCPath", "CONJUR_NETRC_PATH"},
+		{&c.SSLCertPath, "SSLCertPath", "CONJUR_CERT_FILE"},
+		{&c.SSLCert, "SSLCert", "CONJUR_SSL_CERTIFICATE"},
+		{&c.AuthnType, "AuthnType", "CONJUR_AUTHN_TYPE"},
+		{&c.ServiceID, "ServiceID", "CONJUR_SERVICE_ID"},
+		{&c.ClientCert, "ClientCert", "CONJUR_CLIENT_CERT"},
+		{&c.ClientKey, "ClientKey", "CONJUR_CLIENT_KEY"},
+		{&c.ClientCertPath, "ClientCertPath", "CONJUR_CLIENT_CERT_PATH"},
+		{&c.ClientKeyPath, "ClientKeyPath", "CONJUR_CLIENT_KEY_PATH"},
+		{&c.JWTToken, "JWTToken", "CONJUR_AUTHN_JWT_TOKEN"},
+		{&c.JWTTokenPath, "JWTTokenPath", "CONJUR_AUTHN_JWT_TOKEN_PATH"},
+		{&c.JWTHostID, "JWTHostID", "CONJUR_AUTHN_JWT_HOST_ID"},
+		{&c.CredentialStorageType, "CredentialStorageType", "CONJUR_CREDENTIAL_STORAGE"},
+		{&c.CredentialFilePath, "CredentialFilePath", "CONJUR_CREDENTIAL_FILE_PATH"},
+	}
+
+	for _, b := range bindings {
+		if v := os.Getenv(b.env); v != "" {
+			*b.field = v
+			c.setSource(b.name, "env:"+b.env)
+		}
+	}
+}
+
+// recordChangedSources compares c against its state before a merge and
+// attributes any field that changed to source.
+func (c *Config) recordChangedSources(before Config, source string) {
+	if c.Account != before.Account {
+		c.setSource("Account", source)
+	}
+	if c.ApplianceURL != before.ApplianceURL {
+		c.setSource("ApplianceURL", source)
+	}
+	if c.NetRCPath != before.NetRCPath {
+		c.setSource("NetRCPath", source)
+	}
+	if c.SSLCertPath != before.SSLCertPath {
+		c.setSource("SSLCertPath", source)
+	}
+	if c.AuthnType != before.AuthnType {
+		c.setSource("AuthnType", source)
+	}
+	if c.ServiceID != before.ServiceID {
+		c.setSource("ServiceID", source)
+	}
+	if c.ClientCertPath != before.ClientCertPath {
+		c.setSource("ClientCertPath", source)
+	}
+	if c.ClientKeyPath != before.ClientKeyPath {
+		c.setSource("ClientKeyPath", source)
+	}
+	if c.JWTTokenPath != before.JWTTokenPath {
+		c.setSource("JWTTokenPath", source)
+	}
+	if c.JWTHostID != before.JWTHostID {
+		c.setSource("JWTHostID", source)
+	}
+	if c.CredentialStorageType != before.CredentialStorageType {
+		c.setSource("CredentialStorageType", source)
+	}
+	if c.CredentialFilePath != before.CredentialFilePath {
+		c.setSource("CredentialFilePath", source)
+	}
+}
+
+func (c *Config) setSource(field, source string) {
+	if c.sources == nil {
+		c.sources = map[string]string{}
+	}
+	c.sources[field] = source
+}
@@ -0,0 +1,73 @@
+package conjurapi
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigWithOptions_ExplicitPath(t *testing.T) {
+	t.Run("Returns ErrConjurrcNotFound when ExplicitPath does not exist", func(t *testing.T) {
+		_, err := LoadConfigWithOptions(LoadOptions{ExplicitPath: "/path/does/not/exist/.conjurrc"})
+		assert.Error(t, err)
+
+		var notFound ErrConjurrcNotFound
+		assert.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "/path/does/not/exist/.conjurrc", notFound.Path)
+	})
+
+	t.Run("Loads only ExplicitPath, ignoring the default precedence", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		tmpFileName, err := TempFileForTesting("TestLoadOptionsExplicit", "---\naccount: explicit-account\nappliance_url: explicit-url\n", t)
+		defer os.Remove(tmpFileName)
+		assert.NoError(t, err)
+
+		config, err := LoadConfigWithOptions(LoadOptions{ExplicitPath: tmpFileName})
+		assert.NoError(t, err)
+		assert.Equal(t, "explicit-account", config.Account)
+		assert.Equal(t, tmpFileName, config.Sources()["Account"])
+	})
+}
+
+func TestLoadConfigWithOptions_Precedence(t *testing.T) {
+	t.Run("Merges candidate files in order and lets env win", func(t *testing.T) {
+		e := ClearEnv()
+		defer e.RestoreEnv()
+
+		dir := t.TempDir()
+		home := path.Join(dir, "home")
+		assert.NoError(t, os.Mkdir(home, 0755))
+
+		cwd, err := os.Getwd()
+		assert.NoError(t, err)
+		assert.NoError(t, os.Chdir(dir))
+		defer os.Chdir(cwd)
+
+		// Lower-precedence file: $HOME/.conjurrc.
+		homeConjurrc := path.Join(home, ".conjurrc")
+		assert.NoError(t, os.WriteFile(homeConjurrc, []byte("---\naccount: home-account\nappliance_url: home-url\nservice_id: home-service\n"), 0644))
+
+		// Higher-precedence file: ./.conjurrc, overrides appliance_url.
+		cwdConjurrc := path.Join(dir, ".conjurrc")
+		assert.NoError(t, os.WriteFile(cwdConjurrc, []byte("---\nappliance_url: cwd-url\n"), 0644))
+
+		os.Setenv("HOME", home)
+		os.Setenv("CONJUR_SERVICE_ID", "env-service")
+
+		config, err := LoadConfigWithOptions(LoadOptions{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "home-account", config.Account)
+		assert.Equal(t, "cwd-url", config.ApplianceURL)
+		assert.Equal(t, "env-service", config.ServiceID)
+
+		sources := config.Sources()
+		assert.Equal(t, homeConjurrc, sources["Account"])
+		assert.Equal(t, cwdConjurrc, sources["ApplianceURL"])
+		assert.Equal(t, "env:CONJUR_SERVICE_ID", sources["ServiceID"])
+	})
+}
@@ -0,0 +1,38 @@
+package conjurapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Login exchanges a Conjur login name and password for that identity's API
+// key, via the `GET /authn/{account}/login` endpoint. The returned API key
+// is a long-lived credential suitable for storing in a netrc, unlike an
+// access token.
+func (c *Client) Login(login string, password string) (string, error) {
+	url := c.applianceURL("/authn/%s/login", c.config.Account)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("Unable to build login request: %s", err)
+	}
+	req.SetBasicAuth(login, password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Unable to log in: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read login response: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Login failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	return string(body), nil
+}
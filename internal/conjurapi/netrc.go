@@ -0,0 +1,65 @@
+package conjurapi
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bgentry/go-netrc/netrc"
+)
+
+// netRCParseFile parses the netrc file at path, treating a missing file as
+// an empty one rather than an error.
+func netRCParseFile(path string) (*netrc.Netrc, error) {
+	n, err := netrc.ParseFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		return &netrc.Netrc{}, nil
+	}
+	return n, nil
+}
+
+// netRCWriteFile renders n and writes it to path.
+func netRCWriteFile(path string, n *netrc.Netrc) error {
+	out, err := n.MarshalText()
+	if err != nil {
+		return fmt.Errorf("Unable to render %s: %s", path, err)
+	}
+
+	if err := os.WriteFile(path, out, 0600); err != nil {
+		return fmt.Errorf("Unable to write %s: %s", path, err)
+	}
+
+	return nil
+}
+
+// removeMachine deletes machine's entry from n, if present.
+func removeMachine(n *netrc.Netrc, machine string) {
+	n.RemoveMachine(machine)
+}
+
+// writeNetRCEntry sets the login/password for machine in the netrc file at
+// path, creating the file (and any missing parent machine entry) if
+// needed. If the file already has an entry for machine with a different
+// login, writeNetRCEntry refuses to overwrite it rather than guess which
+// one the caller wants.
+func writeNetRCEntry(path string, machine string, login string, password string) error {
+	n, err := netRCParseFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to parse %s: %s", path, err)
+	}
+
+	if m := n.FindMachine(machine); m != nil {
+		if m.Login != "" && m.Login != login {
+			return fmt.Errorf(
+				"%s already has an entry for %s with login %q, refusing to overwrite with %q",
+				path, machine, m.Login, login,
+			)
+		}
+		n.RemoveMachine(machine)
+	}
+	n.NewMachine(machine, login, password, "")
+
+	return netRCWriteFile(path, n)
+}